@@ -0,0 +1,123 @@
+package http
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AcceptedType is a single parsed entry from a media-range header (e.g. Accept), ready
+// to be matched against the MIME types a client or server supports.
+type AcceptedType struct {
+	Spec        MIME
+	Quality     float64
+	Specificity int
+	Params      map[string]string
+}
+
+// ParseAccept parses a media-range header into a list of AcceptedType, sorted by
+// quality (descending), then specificity (descending), then original order.
+func ParseAccept(header string) []AcceptedType {
+	ranges := strings.Split(header, ",")
+	accepted := make([]AcceptedType, 0, len(ranges))
+
+	for _, r := range ranges {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+
+		parts := strings.Split(r, ";")
+		spec := MIME(strings.TrimSpace(parts[0]))
+		quality := 1.0
+		params := make(map[string]string)
+
+		for _, param := range parts[1:] {
+			name, value, ok := splitParam(param)
+			if !ok {
+				continue
+			}
+
+			if name == "q" {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+				continue
+			}
+
+			params[name] = value
+		}
+
+		accepted = append(accepted, AcceptedType{
+			Spec:        spec,
+			Quality:     quality,
+			Specificity: specificity(spec),
+			Params:      params,
+		})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		if accepted[i].Quality != accepted[j].Quality {
+			return accepted[i].Quality > accepted[j].Quality
+		}
+
+		return accepted[i].Specificity > accepted[j].Specificity
+	})
+
+	return accepted
+}
+
+func splitParam(param string) (name, value string, ok bool) {
+	param = strings.TrimSpace(param)
+	idx := strings.IndexByte(param, '=')
+	if idx < 0 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(param[:idx]), strings.Trim(strings.TrimSpace(param[idx+1:]), `"`), true
+}
+
+// specificity ranks a media-range from least to most specific: "*/*" is the lowest,
+// "type/*" is in the middle and a fully qualified "type/subtype" is the highest.
+func specificity(spec MIME) int {
+	value := spec.String()
+	switch {
+	case value == "*/*":
+		return 0
+	case strings.HasSuffix(value, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// baseMIME strips any media-type parameters (e.g. "; charset=UTF-8") from a MIME,
+// leaving just the "type/subtype" to match against registered codecs.
+func baseMIME(mime MIME) MIME {
+	value := mime.String()
+	if idx := strings.IndexByte(value, ';'); idx >= 0 {
+		value = value[:idx]
+	}
+
+	return MIME(strings.TrimSpace(value))
+}
+
+// SetAccept builds an Accept header from the given MIME types, in preference order,
+// assigning explicit q= weights so servers performing content negotiation pick the
+// best match.
+func (r *Request) SetAccept(mimes ...MIME) *Request {
+	if len(mimes) == 0 {
+		return r
+	}
+
+	step := 1.0 / float64(len(mimes))
+	parts := make([]string, 0, len(mimes))
+	for i, mime := range mimes {
+		quality := 1.0 - float64(i)*step
+		parts = append(parts, fmt.Sprintf("%s;q=%.3g", mime.String(), quality))
+	}
+
+	r.SetHeader(HeaderAccept, strings.Join(parts, ", "))
+	return r
+}
@@ -0,0 +1,64 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/FlorentinDUBOIS/go.util/http"
+)
+
+func TestParseAcceptSortsByQualityThenSpecificity(testing *testing.T) {
+	accepted := ParseAccept("text/plain;q=0.5, text/html, */*;q=0.1, text/*;q=0.5")
+	if len(accepted) != 4 {
+		testing.Fatalf("expected 4 entries, got %d", len(accepted))
+	}
+
+	if accepted[0].Spec != MIMETextHTML {
+		testing.Errorf("expected 'text/html' first, got '%s'", accepted[0].Spec)
+	}
+
+	if accepted[1].Spec != "text/plain" {
+		testing.Errorf("expected 'text/plain' second, got '%s'", accepted[1].Spec)
+	}
+
+	if accepted[2].Spec != "text/*" {
+		testing.Errorf("expected 'text/*' third, got '%s'", accepted[2].Spec)
+	}
+
+	if accepted[3].Spec != "*/*" {
+		testing.Errorf("expected '*/*' last, got '%s'", accepted[3].Spec)
+	}
+}
+
+func TestSetAcceptAssignsDescendingQuality(testing *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get(HeaderAccept.String())
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+
+	mapper := make(map[string]interface{})
+	err := NewClient(nil).
+		R().
+		SetAccept(MIMEApplicationJSON, MIMEApplicationXML).
+		Get(server.URL, &mapper)
+
+	if err != nil {
+		testing.Error(err)
+		testing.Fail()
+	}
+
+	accepted := ParseAccept(header)
+	if len(accepted) != 2 {
+		testing.Fatalf("expected 2 entries, got %d", len(accepted))
+	}
+
+	if accepted[0].Spec != MIMEApplicationJSON || accepted[0].Quality <= accepted[1].Quality {
+		testing.Errorf("expected json to carry a higher quality than xml, got %+v", accepted)
+	}
+}
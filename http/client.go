@@ -7,7 +7,8 @@ import (
 
 // Client structure hold http client and methods to interact with APIs.
 type Client struct {
-	inner *http.Client
+	inner       *http.Client
+	retryPolicy *RetryPolicy
 }
 
 // NewClient return a new instance of `Client`.
@@ -41,3 +42,10 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 func (c *Client) R() *Request {
 	return NewRequest(c)
 }
+
+// WithRetry sets the retry policy applied by default to requests created from this
+// client. Pass nil to disable retries.
+func (c *Client) WithRetry(policy *RetryPolicy) *Client {
+	c.retryPolicy = policy
+	return c
+}
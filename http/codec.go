@@ -0,0 +1,246 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"reflect"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec marshals and unmarshals values for a given MIME type. Register custom or
+// overriding codecs with RegisterCodec.
+type Codec interface {
+	Marshal(val interface{}) ([]byte, error)
+	Unmarshal(data []byte, out interface{}) error
+}
+
+// BodyMarshaler is implemented by codecs whose Content-Type is computed per payload
+// (e.g. multipart/form-data's boundary), in addition to the serialized body.
+type BodyMarshaler interface {
+	MarshalBody(val interface{}) (body []byte, contentType string, err error)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[MIME]Codec{
+		MIMEApplicationJSON:     jsonCodec{},
+		MIMEApplicationXML:      xmlCodec{},
+		MIMETextXML:             xmlCodec{},
+		MIMEApplicationProtobuf: protobufCodec{},
+		MIMEApplicationMsgpack:  msgpackCodec{},
+		MIMEApplicationForm:     formCodec{},
+		MIMEMultipartForm:       multipartCodec{},
+	}
+)
+
+// RegisterCodec registers (or overrides) the Codec used to marshal/unmarshal the given
+// MIME type.
+func RegisterCodec(mime MIME, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[baseMIME(mime)] = codec
+}
+
+func codecFor(mime MIME) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[baseMIME(mime)]
+	return codec, ok
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(val interface{}) ([]byte, error)      { return json.Marshal(val) }
+func (jsonCodec) Unmarshal(data []byte, out interface{}) error { return json.Unmarshal(data, out) }
+
+type xmlCodec struct{}
+
+func (xmlCodec) Marshal(val interface{}) ([]byte, error)      { return xml.Marshal(val) }
+func (xmlCodec) Unmarshal(data []byte, out interface{}) error { return xml.Unmarshal(data, out) }
+
+// protobufCodec marshals values implementing proto.Message using the canonical
+// protobuf wire format.
+type protobufCodec struct{}
+
+func (protobufCodec) Marshal(val interface{}) ([]byte, error) {
+	msg, ok := val.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("protobuf codec cannot encode value of type %T, it does not implement proto.Message", val)
+	}
+
+	return proto.Marshal(msg)
+}
+
+func (protobufCodec) Unmarshal(data []byte, out interface{}) error {
+	msg, ok := out.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf codec cannot decode into value of type %T, it does not implement proto.Message", out)
+	}
+
+	return proto.Unmarshal(data, msg)
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(val interface{}) ([]byte, error) { return msgpack.Marshal(val) }
+func (msgpackCodec) Unmarshal(data []byte, out interface{}) error {
+	return msgpack.Unmarshal(data, out)
+}
+
+// formCodec encodes a map[string]string, url.Values or struct (using `form:` tags) as
+// application/x-www-form-urlencoded.
+type formCodec struct{}
+
+func (formCodec) Marshal(val interface{}) ([]byte, error) {
+	values, err := formValues(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(values.Encode()), nil
+}
+
+func (formCodec) Unmarshal(data []byte, out interface{}) error {
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+
+	target, ok := out.(*url.Values)
+	if !ok {
+		return fmt.Errorf("form codec can only decode into a *url.Values, got %T", out)
+	}
+
+	*target = values
+	return nil
+}
+
+func formValues(val interface{}) (url.Values, error) {
+	switch v := val.(type) {
+	case url.Values:
+		return v, nil
+	case map[string]string:
+		values := make(url.Values, len(v))
+		for name, value := range v {
+			values.Set(name, value)
+		}
+
+		return values, nil
+	default:
+		return formValuesFromStruct(val)
+	}
+}
+
+func formValuesFromStruct(val interface{}) (url.Values, error) {
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form codec cannot encode value of kind %s", rv.Kind())
+	}
+
+	rt := rv.Type()
+	values := make(url.Values, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		name := rt.Field(i).Tag.Get("form")
+		if name == "" {
+			name = rt.Field(i).Name
+		}
+
+		if name == "-" {
+			continue
+		}
+
+		values.Set(name, fmt.Sprintf("%v", rv.Field(i).Interface()))
+	}
+
+	return values, nil
+}
+
+// FormField is a single part of a multipart/form-data request. Filename and
+// ContentType are optional and only needed for file uploads.
+type FormField struct {
+	Name        string
+	Filename    string
+	ContentType string
+	Reader      io.Reader
+}
+
+// multipartCodec encodes a map[string]io.Reader or []FormField as multipart/form-data,
+// computing the boundary-bearing Content-Type as it writes the body.
+type multipartCodec struct{}
+
+func (c multipartCodec) Marshal(val interface{}) ([]byte, error) {
+	body, _, err := c.MarshalBody(val)
+	return body, err
+}
+
+func (multipartCodec) Unmarshal(data []byte, out interface{}) error {
+	return fmt.Errorf("decoding multipart/form-data responses is not supported")
+}
+
+func (multipartCodec) MarshalBody(val interface{}) ([]byte, string, error) {
+	fields, err := formFields(val)
+	if err != nil {
+		return nil, "", err
+	}
+
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+
+	for _, field := range fields {
+		var part io.Writer
+		if field.Filename != "" {
+			header := make(textproto.MIMEHeader)
+			header.Set(HeaderContentDisposition.String(), fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field.Name, field.Filename))
+			if field.ContentType != "" {
+				header.Set(HeaderContentType.String(), field.ContentType)
+			}
+
+			part, err = writer.CreatePart(header)
+		} else {
+			part, err = writer.CreateFormField(field.Name)
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _, err = io.Copy(part, field.Reader); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+func formFields(val interface{}) ([]FormField, error) {
+	switch v := val.(type) {
+	case []FormField:
+		return v, nil
+	case map[string]io.Reader:
+		fields := make([]FormField, 0, len(v))
+		for name, reader := range v {
+			fields = append(fields, FormField{Name: name, Reader: reader})
+		}
+
+		return fields, nil
+	default:
+		return nil, fmt.Errorf("multipart codec cannot encode value of type %T", val)
+	}
+}
@@ -0,0 +1,74 @@
+package http_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/FlorentinDUBOIS/go.util/http"
+)
+
+func TestFormCodecEncodesMap(testing *testing.T) {
+	var contentType, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get(HeaderContentType.String())
+		buf := make([]byte, r.ContentLength)
+		r.Body.Read(buf)
+		body = string(buf)
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+
+	mapper := make(map[string]interface{})
+	err := NewClient(nil).
+		R().
+		SetHeader(HeaderContentType, MIMEApplicationForm.String()).
+		SetBody(map[string]string{"foo": "bar"}).
+		Post(server.URL, &mapper)
+
+	if err != nil {
+		testing.Error(err)
+		testing.Fail()
+	}
+
+	if contentType != MIMEApplicationForm.String() {
+		testing.Errorf("expected content type '%s', got '%s'", MIMEApplicationForm.String(), contentType)
+	}
+
+	if body != "foo=bar" {
+		testing.Errorf("expected body 'foo=bar', got '%s'", body)
+	}
+}
+
+func TestMultipartCodecSetsBoundaryContentType(testing *testing.T) {
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get(HeaderContentType.String())
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+
+	mapper := make(map[string]interface{})
+	err := NewClient(nil).
+		R().
+		SetHeader(HeaderContentType, MIMEMultipartForm.String()).
+		SetBody(map[string]io.Reader{"foo": strings.NewReader("bar")}).
+		Post(server.URL, &mapper)
+
+	if err != nil {
+		testing.Error(err)
+		testing.Fail()
+	}
+
+	if !strings.HasPrefix(contentType, "multipart/form-data; boundary=") {
+		testing.Errorf("expected a boundary-bearing content type, got '%s'", contentType)
+	}
+}
@@ -92,6 +92,7 @@ const (
 	HeaderIfModifiedSince     Header = "If-Modified-Since"
 	HeaderLastModified        Header = "Last-Modified"
 	HeaderLocation            Header = "Location"
+	HeaderRetryAfter          Header = "Retry-After"
 	HeaderUpgrade             Header = "Upgrade"
 	HeaderVary                Header = "Vary"
 	HeaderWWWAuthenticate     Header = "WWW-Authenticate"
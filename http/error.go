@@ -0,0 +1,23 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// HTTPError is returned when a request completes with a status the caller did not
+// expect to succeed. It carries the raw response so callers can inspect RFC 7807
+// problem+json payloads, rate-limit headers or validation errors instead of losing
+// them to a plain error string.
+type HTTPError struct {
+	Status Status
+	Header http.Header
+	Body   []byte
+	URL    string
+	Method string
+}
+
+// Error is the error implementation
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s %s: http request failed, got status: %s", e.Method, e.URL, e.Status.String())
+}
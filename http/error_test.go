@@ -0,0 +1,65 @@
+package http_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/FlorentinDUBOIS/go.util/http"
+)
+
+func TestDoReturnsHTTPErrorWithBodyOnNonSuccess(testing *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"invalid"}`))
+	}))
+
+	defer server.Close()
+
+	problem := make(map[string]interface{})
+	mapper := make(map[string]interface{})
+	err := NewClient(nil).
+		R().
+		SetErrorBody(&problem).
+		Get(server.URL, &mapper)
+
+	if err == nil {
+		testing.Fatal("expected an error")
+	}
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		testing.Fatalf("expected an *HTTPError, got %T", err)
+	}
+
+	if httpErr.Status != StatusBadRequest {
+		testing.Errorf("expected status %s, got %s", StatusBadRequest, httpErr.Status)
+	}
+
+	if problem["message"] != "invalid" {
+		testing.Errorf("expected error body to be decoded, got %+v", problem)
+	}
+}
+
+func TestSetExpectedStatusAcceptsNonSuccess(testing *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+
+	mapper := make(map[string]interface{})
+	err := NewClient(nil).
+		R().
+		SetExpectedStatus(StatusNotFound).
+		Get(server.URL, &mapper)
+
+	if err != nil {
+		testing.Error(err)
+		testing.Fail()
+	}
+}
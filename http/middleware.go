@@ -0,0 +1,197 @@
+package http
+
+import (
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"runtime/debug"
+	"time"
+)
+
+// Middleware wraps a http.RoundTripper with additional behavior, following the same
+// composition pattern popular server-side middleware stacks use, but for the outbound
+// client direction this module provides.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// roundTripperFunc is an adapter allowing a plain function to satisfy http.RoundTripper.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip is the http.RoundTripper implementation
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Use wraps the client's underlying transport with the given middlewares. Middlewares
+// are applied in order, so the first middleware is the outermost one invoked on Do.
+func (c *Client) Use(middlewares ...Middleware) *Client {
+	transport := c.inner.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+
+	c.inner.Transport = transport
+	return c
+}
+
+// RequestIDMiddleware generates a random X-Request-ID header when the request does not
+// already carry one, so downstream services and logs can correlate calls.
+func RequestIDMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(HeaderXRequestID.String()) == "" {
+				id, err := newRequestID()
+				if err != nil {
+					return nil, err
+				}
+
+				req.Header.Set(HeaderXRequestID.String(), id)
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func newRequestID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// LogMiddleware logs each request/response pair as a Common Log Format line, including
+// the request duration. A nil output defaults to os.Stderr.
+func LogMiddleware(output io.Writer) Middleware {
+	if output == nil {
+		output = os.Stderr
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.RoundTrip(req)
+			duration := time.Since(start)
+
+			host := req.URL.Hostname()
+			if host == "" {
+				host = "-"
+			}
+
+			status, length := "-", "-"
+			if res != nil {
+				status = fmt.Sprintf("%d", res.StatusCode)
+				length = fmt.Sprintf("%d", res.ContentLength)
+			}
+
+			fmt.Fprintf(
+				output,
+				"%s - - [%s] \"%s %s %s\" %s %s %s\n",
+				host,
+				start.Format("02/Jan/2006:15:04:05 -0700"),
+				req.Method,
+				req.URL.RequestURI(),
+				req.Proto,
+				status,
+				length,
+				duration,
+			)
+
+			return res, err
+		})
+	}
+}
+
+// GzipMiddleware transparently advertises gzip support via Accept-Encoding and decodes
+// gzip-encoded responses before handing them back to the caller.
+func GzipMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set(HeaderAcceptEncoding.String(), "gzip")
+
+			res, err := next.RoundTrip(req)
+			if err != nil || res == nil {
+				return res, err
+			}
+
+			if res.Header.Get(HeaderContentEncoding.String()) != "gzip" {
+				return res, nil
+			}
+
+			reader, err := gzip.NewReader(res.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			res.Body = &gzipReadCloser{reader: reader, body: res.Body}
+			res.Header.Del(HeaderContentEncoding.String())
+			res.ContentLength = -1
+
+			return res, nil
+		})
+	}
+}
+
+// gzipReadCloser decodes a gzip-compressed response body lazily, as the caller reads
+// it, so the underlying network connection must stay open until Close is called.
+type gzipReadCloser struct {
+	reader *gzip.Reader
+	body   io.ReadCloser
+}
+
+func (g *gzipReadCloser) Read(p []byte) (int, error) {
+	return g.reader.Read(p)
+}
+
+func (g *gzipReadCloser) Close() error {
+	if err := g.reader.Close(); err != nil {
+		g.body.Close()
+		return err
+	}
+
+	return g.body.Close()
+}
+
+// RecoveryMiddleware recovers from panics raised by downstream transports and surfaces
+// them as an error instead of crashing the caller.
+func RecoveryMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (res *http.Response, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("recovered from panic in transport: %v\n%s", r, debug.Stack())
+				}
+			}()
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// CleanPathMiddleware normalizes the request URL path, collapsing "//" and resolving
+// ".." segments before the request is sent.
+func CleanPathMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "" {
+				return next.RoundTrip(req)
+			}
+
+			if cleaned := path.Clean(req.URL.Path); cleaned != req.URL.Path {
+				req.URL.Path = cleaned
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/FlorentinDUBOIS/go.util/http"
+)
+
+func TestRequestIDMiddlewarePropagatesHeader(testing *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(HeaderXRequestID.String())
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+	client := NewClient(nil).Use(RequestIDMiddleware())
+
+	mapper := make(map[string]interface{})
+	if err := client.R().Get(server.URL, &mapper); err != nil {
+		testing.Error(err)
+		testing.Fail()
+	}
+
+	if got == "" {
+		testing.Error("expected X-Request-ID header to be set")
+		testing.Fail()
+	}
+}
+
+func TestCleanPathMiddlewareNormalizesPath(testing *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+	client := NewClient(nil).Use(CleanPathMiddleware())
+
+	mapper := make(map[string]interface{})
+	if err := client.R().Get(server.URL+"/foo//../bar", &mapper); err != nil {
+		testing.Error(err)
+		testing.Fail()
+	}
+
+	if path != "/bar" {
+		testing.Errorf("expected path to be normalized to '/bar', got '%s'", path)
+		testing.Fail()
+	}
+}
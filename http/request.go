@@ -4,24 +4,28 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Request structure
 type Request struct {
-	client      *Client
-	headers     map[Header]string
-	queryParams map[string]string
-	pathParams  map[string]string
-	context     context.Context
-	body        interface{}
+	client         *Client
+	headers        map[Header]string
+	queryParams    map[string]string
+	pathParams     map[string]string
+	context        context.Context
+	body           interface{}
+	bodyReader     io.Reader
+	bodyReaderLen  int64
+	retryPolicy    *RetryPolicy
+	errorBody      interface{}
+	expectedStatus []Status
 }
 
 // NewRequest return a new instance of `Request` using the given `Client`
@@ -37,6 +41,7 @@ func NewRequest(client *Client) *Request {
 		pathParams:  make(map[string]string),
 		context:     context.Background(),
 		body:        nil,
+		retryPolicy: client.retryPolicy,
 	}
 }
 
@@ -92,6 +97,71 @@ func (r *Request) SetBody(body interface{}) *Request {
 	return r
 }
 
+// SetRetryPolicy overrides the retry policy used for this request. Pass nil to disable
+// retries, even if the client has one configured by default.
+func (r *Request) SetRetryPolicy(policy *RetryPolicy) *Request {
+	r.retryPolicy = policy
+	return r
+}
+
+// SetErrorBody registers a struct to decode non-2xx response bodies into, using the
+// same MIME-dispatch as success responses. The raw body remains available on the
+// returned *HTTPError regardless.
+func (r *Request) SetErrorBody(body interface{}) *Request {
+	r.errorBody = body
+	return r
+}
+
+// SetExpectedStatus overrides the default "2xx means success" rule, letting callers
+// treat specific non-2xx statuses (e.g. 404) as a valid result instead of an error.
+func (r *Request) SetExpectedStatus(statuses ...Status) *Request {
+	r.expectedStatus = statuses
+	return r
+}
+
+func (r *Request) isExpected(status Status) bool {
+	if len(r.expectedStatus) == 0 {
+		return status.IsSuccess()
+	}
+
+	for _, expected := range r.expectedStatus {
+		if expected == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleError reads and closes the response body, optionally decoding it into the
+// registered error body, and returns it wrapped in an *HTTPError.
+func (r *Request) handleError(res *http.Response, status Status, method Method, URL string) error {
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, res.Body); err != nil {
+		res.Body.Close()
+		return err
+	}
+
+	if err := res.Body.Close(); err != nil {
+		return err
+	}
+
+	body := buf.Bytes()
+	if r.errorBody != nil {
+		if val := res.Header.Get(HeaderContentType.String()); val != "" {
+			_ = r.unmarshall(NewMIME(val), bytes.NewReader(body), r.errorBody)
+		}
+	}
+
+	return &HTTPError{
+		Status: status,
+		Header: res.Header,
+		Body:   body,
+		URL:    URL,
+		Method: method.String(),
+	}
+}
+
 func (r *Request) marshal(val interface{}) ([]byte, error) {
 	if val == nil {
 		return make([]byte, 0), nil
@@ -102,14 +172,22 @@ func (r *Request) marshal(val interface{}) ([]byte, error) {
 		return nil, fmt.Errorf("%s is not defined", HeaderContentType.String())
 	}
 
-	switch NewMIME(mime) {
-	case MIMEApplicationJSON, MIMEApplicationJSONCharsetUTF8:
-		return json.Marshal(val)
-	case MIMEApplicationXML, MIMEApplicationXMLCharsetUTF8:
-		return xml.Marshal(val)
-	default:
-		return nil, fmt.Errorf("serializing format '%s' is not supported", val)
+	codec, ok := codecFor(NewMIME(mime))
+	if !ok {
+		return nil, fmt.Errorf("serializing format '%s' is not supported", mime)
+	}
+
+	if marshaler, ok := codec.(BodyMarshaler); ok {
+		body, contentType, err := marshaler.MarshalBody(val)
+		if err != nil {
+			return nil, err
+		}
+
+		r.SetHeader(HeaderContentType, contentType)
+		return body, nil
 	}
+
+	return codec.Marshal(val)
 }
 
 func (r *Request) unmarshall(mime MIME, reader io.Reader, out interface{}) error {
@@ -118,22 +196,79 @@ func (r *Request) unmarshall(mime MIME, reader io.Reader, out interface{}) error
 		return err
 	}
 
-	switch mime {
-	case MIMEApplicationJSON, MIMEApplicationJSONCharsetUTF8:
-		return json.Unmarshal(body, out)
-	case MIMEApplicationXML, MIMEApplicationXMLCharsetUTF8:
-		return xml.Unmarshal(body, out)
-	default:
+	codec, ok := codecFor(mime)
+	if !ok {
 		return fmt.Errorf("deserializing format '%s' is not supported", mime.String())
 	}
+
+	return codec.Unmarshal(body, out)
 }
 
-func (r *Request) Do(method Method, URL string, out interface{}) error {
-	body, err := r.marshal(r.body)
-	if err != nil {
-		return err
+// do sends the request, transparently retrying it according to the request's
+// RetryPolicy when one is set and applies to the given method.
+func (r *Request) do(method Method, URL string, body []byte) (*http.Response, error) {
+	attempts := 1
+	policy := r.retryPolicy
+	if policy != nil && policy.allows(method) {
+		attempts = policy.MaxAttempts
+		if attempts < 1 {
+			attempts = 1
+		}
+	}
+
+	var res *http.Response
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			wait := policy.backoff(attempt - 1)
+			if res != nil {
+				if after, ok := retryAfter(res.Header.Get(HeaderRetryAfter.String())); ok {
+					wait = after
+				}
+				io.Copy(ioutil.Discard, res.Body)
+				res.Body.Close()
+			}
+
+			if policy.OnRetry != nil {
+				policy.OnRetry(attempt, lastErr)
+			}
+
+			select {
+			case <-r.context.Done():
+				return nil, r.context.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		req, err := http.NewRequest(method.String(), URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		req = req.WithContext(r.context)
+		for name, value := range r.headers {
+			req.Header.Set(name.String(), value)
+		}
+
+		res, lastErr = r.client.Do(req)
+		if policy == nil || !policy.allows(method) {
+			break
+		}
+
+		if policy.ShouldRetry == nil || !policy.ShouldRetry(res, lastErr) {
+			break
+		}
 	}
 
+	if lastErr != nil {
+		return nil, lastErr
+	}
+
+	return res, nil
+}
+
+// buildURL resolves query and path parameters against URL.
+func (r *Request) buildURL(URL string) string {
 	params := make([]string, 0)
 	for name, value := range r.queryParams {
 		params = append(params, fmt.Sprintf("%s=%s", url.QueryEscape(name), url.QueryEscape(value)))
@@ -144,25 +279,34 @@ func (r *Request) Do(method Method, URL string, out interface{}) error {
 		URL = strings.Replace(URL, name, url.QueryEscape(value), -1)
 	}
 
-	r.SetHeader(HeaderContentLength, fmt.Sprintf("%d", len(body)))
-	req, err := http.NewRequest(method.String(), URL, bytes.NewReader(body))
-	if err != nil {
-		return err
-	}
+	return URL
+}
 
-	req = req.WithContext(r.context)
-	for name, value := range r.headers {
-		req.Header.Set(name.String(), value)
+func (r *Request) Do(method Method, URL string, out interface{}) error {
+	URL = r.buildURL(URL)
+
+	var res *http.Response
+	var err error
+	if r.bodyReader != nil {
+		res, err = r.doReader(method, URL, r.bodyReader, r.bodyReaderLen)
+	} else {
+		var body []byte
+		body, err = r.marshal(r.body)
+		if err != nil {
+			return err
+		}
+
+		r.SetHeader(HeaderContentLength, fmt.Sprintf("%d", len(body)))
+		res, err = r.do(method, URL, body)
 	}
 
-	res, err := r.client.Do(req)
 	if err != nil {
 		return err
 	}
 
 	status := NewStatus(res.StatusCode)
-	if !status.IsSuccess() {
-		return fmt.Errorf("http request failed, got status: %s", status.String())
+	if !r.isExpected(status) {
+		return r.handleError(res, status, method, URL)
 	}
 
 	buf := new(bytes.Buffer)
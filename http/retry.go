@@ -0,0 +1,102 @@
+package http
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how a Request retries failed attempts: how many times, how
+// long to wait between attempts and which responses are worth retrying.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseBackoff is the backoff duration used for the first retry.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the computed backoff duration.
+	MaxBackoff time.Duration
+	// Jitter is the fraction of the backoff duration that may be added or removed at
+	// random, to avoid synchronized retries across clients.
+	Jitter float64
+	// RetryableMethods lists the methods eligible for retry. Non-idempotent methods are
+	// excluded by default since resending them may duplicate side effects.
+	RetryableMethods []Method
+	// ShouldRetry classifies whether a given response/error pair should be retried.
+	ShouldRetry func(res *http.Response, err error) bool
+	// OnRetry, when set, is invoked before each retry attempt for observability.
+	OnRetry func(attempt int, err error)
+}
+
+// DefaultRetryPolicy retries idempotent methods on network errors and common transient
+// status codes, backing off exponentially with jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		Jitter:      0.2,
+		RetryableMethods: []Method{
+			MethodGet, MethodHead, MethodPut, MethodDelete, MethodOptions,
+		},
+		ShouldRetry: defaultShouldRetry,
+	}
+}
+
+func defaultShouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch NewStatus(res.StatusCode) {
+	case StatusRequestTimeout, StatusTooManyRequests, StatusBadGateway, StatusServiceUnavailable, StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *RetryPolicy) allows(method Method) bool {
+	for _, m := range p.RetryableMethods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff computes the delay before the given retry attempt (0-indexed), as
+// delay = min(MaxBackoff, BaseBackoff*2^attempt), then delay +/- delay*rand*Jitter.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseBackoff) * math.Pow(2, float64(attempt))
+	if max := float64(p.MaxBackoff); p.MaxBackoff > 0 && delay > max {
+		delay = max
+	}
+
+	delay += delay * p.Jitter * (rand.Float64()*2 - 1)
+	if delay < 0 {
+		delay = 0
+	}
+
+	return time.Duration(delay)
+}
+
+// retryAfter parses a Retry-After header, supporting both the delta-seconds and the
+// HTTP-date forms described in RFC 7231 section 7.1.3.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return time.Until(date), true
+	}
+
+	return 0, false
+}
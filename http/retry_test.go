@@ -0,0 +1,70 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/FlorentinDUBOIS/go.util/http"
+)
+
+func TestRetryPolicyRetriesOnServiceUnavailable(testing *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+
+	mapper := make(map[string]interface{})
+	err := NewClient(nil).
+		R().
+		SetRetryPolicy(policy).
+		Get(server.URL, &mapper)
+
+	if err != nil {
+		testing.Error(err)
+		testing.Fail()
+	}
+
+	if attempts != 3 {
+		testing.Errorf("expected 3 attempts, got %d", attempts)
+		testing.Fail()
+	}
+}
+
+func TestRetryPolicyDoesNotRetryNonIdempotentMethods(testing *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+
+	mapper := make(map[string]interface{})
+	if err := NewClient(nil).R().SetRetryPolicy(policy).Post(server.URL, &mapper); err == nil {
+		testing.Error("expected an error for non-success status")
+		testing.Fail()
+	}
+
+	if attempts != 1 {
+		testing.Errorf("expected a single attempt for a non-idempotent method, got %d", attempts)
+		testing.Fail()
+	}
+}
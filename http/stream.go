@@ -0,0 +1,184 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SetBodyReader sets a raw io.Reader as the request body, bypassing marshal entirely.
+// Use this to stream uploads without buffering them in memory first. contentLength may
+// be -1 if unknown, in which case the request is sent chunked.
+func (r *Request) SetBodyReader(body io.Reader, contentLength int64) *Request {
+	r.bodyReader = body
+	r.bodyReaderLen = contentLength
+	return r
+}
+
+// DoRaw sends the request and returns the raw *http.Response without consuming or
+// closing its body, so callers can stream large downloads themselves. A marshaled body
+// is retried according to the request's RetryPolicy; a body set with SetBodyReader is
+// sent once since it cannot be rewound.
+func (r *Request) DoRaw(method Method, URL string) (*http.Response, error) {
+	URL = r.buildURL(URL)
+
+	if r.bodyReader != nil {
+		return r.doReader(method, URL, r.bodyReader, r.bodyReaderLen)
+	}
+
+	body, err := r.marshal(r.body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.SetHeader(HeaderContentLength, fmt.Sprintf("%d", len(body)))
+	return r.do(method, URL, body)
+}
+
+func (r *Request) doReader(method Method, URL string, body io.Reader, contentLength int64) (*http.Response, error) {
+	req, err := http.NewRequest(method.String(), URL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(r.context)
+	req.ContentLength = contentLength
+	for name, value := range r.headers {
+		req.Header.Set(name.String(), value)
+	}
+
+	return r.client.Do(req)
+}
+
+// DoStream sends the request and hands the raw, unbuffered response body to fn, so
+// callers can process chunked, NDJSON or SSE responses without buffering the whole
+// payload into memory.
+func (r *Request) DoStream(method Method, URL string, fn func(io.Reader) error) error {
+	res, err := r.DoRaw(method, URL)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	status := NewStatus(res.StatusCode)
+	if !r.isExpected(status) {
+		return r.handleError(res, status, method, URL)
+	}
+
+	return fn(res.Body)
+}
+
+// Event is a single parsed text/event-stream message.
+//
+// @see: https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+type Event struct {
+	ID   string
+	Name string
+	Data string
+}
+
+// EventStream reads a text/event-stream body and delivers parsed Event values on
+// Events until the underlying reader is exhausted, at which point Events is closed and
+// any read error is available on Err.
+type EventStream struct {
+	Events chan Event
+	Err    error
+}
+
+// NewEventStream starts reading reader in a goroutine, splitting it into events on
+// blank lines ("\n\n"; CRLF-delimited blank lines are not recognised) and parsing
+// "event:"/"data:"/"id:" fields from each one. The caller must either range over
+// Events until it is closed (reader exhausted) or cancel ctx to tear down the goroutine
+// early; otherwise it leaks, pinning reader open forever. Cancelling ctx also closes
+// reader when it implements io.Closer, which releases a goroutine blocked on a read.
+func NewEventStream(ctx context.Context, reader io.Reader) *EventStream {
+	stream := &EventStream{
+		Events: make(chan Event),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			if closer, ok := reader.(io.Closer); ok {
+				closer.Close()
+			}
+		case <-done:
+		}
+	}()
+
+	go func() {
+		defer close(stream.Events)
+		defer close(done)
+
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		scanner.Split(splitOnBlankLine)
+
+		for scanner.Scan() {
+			select {
+			case stream.Events <- parseEvent(scanner.Text()):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		stream.Err = scanner.Err()
+	}()
+
+	return stream
+}
+
+func splitOnBlankLine(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if idx := bytes.Index(data, []byte("\n\n")); idx >= 0 {
+		return idx + 2, data[:idx], nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+func parseEvent(block string) Event {
+	event := Event{}
+	data := make([]string, 0)
+
+	for _, line := range strings.Split(block, "\n") {
+		name, value, ok := splitSSEField(strings.TrimRight(line, "\r"))
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case "event":
+			event.Name = value
+		case "id":
+			event.ID = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	event.Data = strings.Join(data, "\n")
+	return event
+}
+
+func splitSSEField(line string) (name, value string, ok bool) {
+	idx := strings.IndexByte(line, ':')
+	if idx < 0 {
+		return "", "", false
+	}
+
+	return line[:idx], strings.TrimPrefix(line[idx+1:], " "), true
+}
@@ -0,0 +1,136 @@
+package http_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/FlorentinDUBOIS/go.util/http"
+)
+
+func TestDoRawReturnsUnconsumedBody(testing *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("hello"))
+	}))
+
+	defer server.Close()
+
+	res, err := NewClient(nil).R().DoRaw(MethodGet, server.URL)
+	if err != nil {
+		testing.Fatal(err)
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		testing.Fatal(err)
+	}
+
+	if string(body) != "hello" {
+		testing.Errorf("expected body 'hello', got '%s'", body)
+	}
+}
+
+func TestSetBodyReaderStreamsUpload(testing *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	reader := strings.NewReader("streamed-body")
+	_, err := NewClient(nil).
+		R().
+		SetBodyReader(reader, reader.Size()).
+		DoRaw(MethodPost, server.URL)
+
+	if err != nil {
+		testing.Fatal(err)
+	}
+
+	if received != "streamed-body" {
+		testing.Errorf("expected 'streamed-body', got '%s'", received)
+	}
+}
+
+func TestNewEventStreamParsesEvents(testing *testing.T) {
+	reader := strings.NewReader("event: ping\ndata: one\n\ndata: two\nid: 42\n\n")
+	stream := NewEventStream(context.Background(), reader)
+
+	events := make([]Event, 0, 2)
+	for event := range stream.Events {
+		events = append(events, event)
+	}
+
+	if stream.Err != nil {
+		testing.Fatal(stream.Err)
+	}
+
+	if len(events) != 2 {
+		testing.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	if events[0].Name != "ping" || events[0].Data != "one" {
+		testing.Errorf("unexpected first event: %+v", events[0])
+	}
+
+	if events[1].ID != "42" || events[1].Data != "two" {
+		testing.Errorf("unexpected second event: %+v", events[1])
+	}
+}
+
+func TestNewEventStreamStopsOnContextCancel(testing *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer := io.Pipe()
+	stream := NewEventStream(ctx, reader)
+
+	go func() {
+		writer.Write([]byte("data: first\n\n"))
+	}()
+
+	<-stream.Events
+	cancel()
+
+	if _, ok := <-stream.Events; ok {
+		testing.Error("expected Events to be closed after the context was cancelled")
+	}
+
+	writer.Close()
+}
+
+func TestDoHonorsBodyReader(testing *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+		w.Header().Set(HeaderContentType.String(), MIMEApplicationJSONCharsetUTF8.String())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+
+	reader := strings.NewReader("streamed-body")
+	mapper := make(map[string]interface{})
+	err := NewClient(nil).
+		R().
+		SetBodyReader(reader, reader.Size()).
+		Post(server.URL, &mapper)
+
+	if err != nil {
+		testing.Fatal(err)
+	}
+
+	if received != "streamed-body" {
+		testing.Errorf("expected 'streamed-body', got '%s'", received)
+	}
+}
+